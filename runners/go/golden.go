@@ -0,0 +1,44 @@
+// ABOUTME: Golden file persistence for --record/--replay: recorded LLM outputs live at golden/<eval_id>.json.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type goldenRecord struct {
+	EvalID  string   `json:"eval_id"`
+	Outputs []string `json:"outputs"`
+}
+
+func loadGolden(path string) (goldenRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return goldenRecord{}, fmt.Errorf("reading golden file %s: %w", path, err)
+	}
+
+	var record goldenRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return goldenRecord{}, fmt.Errorf("parsing golden file %s: %w", path, err)
+	}
+	return record, nil
+}
+
+func saveGolden(path string, record goldenRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating golden dir for %s: %w", path, err)
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding golden file %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing golden file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// ABOUTME: Parses and evaluates LLM eval `then` clauses: equals, contains, regex, json_schema, semantic_similarity.
+// ABOUTME: json_schema validation covers the subset (type/required/properties/items) evals actually need.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ThenClause is one assertion in an llm eval's `then` array. Exactly one of
+// its fields should be set; evaluateClause treats an empty clause as a
+// malformed eval rather than a vacuous pass.
+type ThenClause struct {
+	Equals             *string                   `json:"equals,omitempty"`
+	Contains           *string                   `json:"contains,omitempty"`
+	Regex              *string                   `json:"regex,omitempty"`
+	JSONSchema         json.RawMessage           `json:"json_schema,omitempty"`
+	SemanticSimilarity *semanticSimilarityClause `json:"semantic_similarity,omitempty"`
+}
+
+type semanticSimilarityClause struct {
+	Text      string  `json:"text"`
+	Threshold float64 `json:"threshold"`
+}
+
+// parseThenClauses accepts a `then` field shaped as either a single clause
+// object or an array of clauses, matching how `given`/`when` are already
+// free-form JSON on Eval.
+func parseThenClauses(raw json.RawMessage) ([]ThenClause, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("then is empty")
+	}
+
+	var clauses []ThenClause
+	if err := json.Unmarshal(raw, &clauses); err == nil {
+		if len(clauses) == 0 {
+			return nil, fmt.Errorf("then has no assertions")
+		}
+		return clauses, nil
+	}
+
+	var single ThenClause
+	if err := json.Unmarshal(raw, &single); err != nil {
+		return nil, fmt.Errorf("parsing then: %w", err)
+	}
+	return []ThenClause{single}, nil
+}
+
+// evaluateThenClauses runs every clause against output and reports the
+// first failure; all clauses must pass for the attempt to pass.
+func evaluateThenClauses(ctx context.Context, clauses []ThenClause, output string) (bool, string) {
+	for _, clause := range clauses {
+		if ok, reason := evaluateClause(ctx, clause, output); !ok {
+			return false, reason
+		}
+	}
+	return true, ""
+}
+
+func evaluateClause(ctx context.Context, c ThenClause, output string) (bool, string) {
+	switch {
+	case c.Equals != nil:
+		if output != *c.Equals {
+			return false, fmt.Sprintf("expected output to equal %q, got %q", *c.Equals, output)
+		}
+		return true, ""
+
+	case c.Contains != nil:
+		if !strings.Contains(output, *c.Contains) {
+			return false, fmt.Sprintf("expected output to contain %q", *c.Contains)
+		}
+		return true, ""
+
+	case c.Regex != nil:
+		re, err := regexp.Compile(*c.Regex)
+		if err != nil {
+			return false, fmt.Sprintf("invalid regex %q: %v", *c.Regex, err)
+		}
+		if !re.MatchString(output) {
+			return false, fmt.Sprintf("expected output to match regex %q", *c.Regex)
+		}
+		return true, ""
+
+	case len(c.JSONSchema) > 0:
+		if err := validateJSONSchema(c.JSONSchema, output); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+
+	case c.SemanticSimilarity != nil:
+		outputEmbedding, err := llmProvider.Embed(ctx, output)
+		if err != nil {
+			return false, fmt.Sprintf("semantic_similarity: embedding output: %v", err)
+		}
+		wantEmbedding, err := llmProvider.Embed(ctx, c.SemanticSimilarity.Text)
+		if err != nil {
+			return false, fmt.Sprintf("semantic_similarity: embedding expected text: %v", err)
+		}
+		sim := cosineSimilarity(outputEmbedding, wantEmbedding)
+		if sim < c.SemanticSimilarity.Threshold {
+			return false, fmt.Sprintf("semantic similarity %.3f below threshold %.3f", sim, c.SemanticSimilarity.Threshold)
+		}
+		return true, ""
+
+	default:
+		return false, "then clause has no recognized assertion"
+	}
+}
+
+// validateJSONSchema checks output (which must itself be a JSON document)
+// against a subset of JSON Schema: type, required, properties, and items.
+// That subset covers the shapes our evals assert on without pulling in a
+// third-party schema library.
+func validateJSONSchema(schema json.RawMessage, output string) error {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		return fmt.Errorf("json_schema: output is not valid JSON: %w", err)
+	}
+
+	var sch map[string]interface{}
+	if err := json.Unmarshal(schema, &sch); err != nil {
+		return fmt.Errorf("json_schema: invalid schema: %w", err)
+	}
+
+	return matchSchema(doc, sch, "$")
+}
+
+func matchSchema(doc interface{}, sch map[string]interface{}, path string) error {
+	if wantType, ok := sch["type"].(string); ok {
+		if err := matchType(doc, wantType, path); err != nil {
+			return err
+		}
+	}
+
+	if required, ok := sch["required"].([]interface{}); ok {
+		obj, isObj := doc.(map[string]interface{})
+		if !isObj {
+			return fmt.Errorf("json_schema: %s: required fields specified but value is not an object", path)
+		}
+		for _, field := range required {
+			name, _ := field.(string)
+			if _, present := obj[name]; !present {
+				return fmt.Errorf("json_schema: %s: missing required field %q", path, name)
+			}
+		}
+	}
+
+	if properties, ok := sch["properties"].(map[string]interface{}); ok {
+		obj, isObj := doc.(map[string]interface{})
+		if isObj {
+			for name, propSchemaRaw := range properties {
+				propSchema, ok := propSchemaRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				value, present := obj[name]
+				if !present {
+					continue
+				}
+				if err := matchSchema(value, propSchema, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if itemsSchemaRaw, ok := sch["items"]; ok {
+		itemsSchema, ok := itemsSchemaRaw.(map[string]interface{})
+		arr, isArr := doc.([]interface{})
+		if ok && isArr {
+			for i, item := range arr {
+				if err := matchSchema(item, itemsSchema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchType(doc interface{}, wantType, path string) error {
+	switch wantType {
+	case "string":
+		if _, ok := doc.(string); !ok {
+			return fmt.Errorf("json_schema: %s: expected string, got %T", path, doc)
+		}
+	case "number":
+		if _, ok := doc.(float64); !ok {
+			return fmt.Errorf("json_schema: %s: expected number, got %T", path, doc)
+		}
+	case "integer":
+		f, ok := doc.(float64)
+		if !ok || f != float64(int64(f)) {
+			return fmt.Errorf("json_schema: %s: expected integer, got %T", path, doc)
+		}
+	case "boolean":
+		if _, ok := doc.(bool); !ok {
+			return fmt.Errorf("json_schema: %s: expected boolean, got %T", path, doc)
+		}
+	case "object":
+		if _, ok := doc.(map[string]interface{}); !ok {
+			return fmt.Errorf("json_schema: %s: expected object, got %T", path, doc)
+		}
+	case "array":
+		if _, ok := doc.([]interface{}); !ok {
+			return fmt.Errorf("json_schema: %s: expected array, got %T", path, doc)
+		}
+	case "null":
+		if doc != nil {
+			return fmt.Errorf("json_schema: %s: expected null, got %T", path, doc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// ABOUTME: The llm category runner: repeat-and-pass-rate, retries on transient errors, record/replay.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// AttemptResult is one repeat of an llm eval: the raw output and whether it
+// satisfied the eval's then clauses. Exposed on Result/JsonEvalResult so
+// `--repeat` runs can be inspected attempt-by-attempt in the JSON report.
+type AttemptResult struct {
+	Output string `json:"output"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func runLLMEval(ctx context.Context, eval Eval) Result {
+	opts := llmOptionsFromContext(ctx)
+
+	clauses, err := parseThenClauses(eval.Then)
+	if err != nil {
+		return Result{Status: Fail, Reason: fmt.Sprintf("invalid then clause: %v", err)}
+	}
+
+	minPassRate := opts.MinPassRate
+	if eval.MinPassRate != nil {
+		minPassRate = *eval.MinPassRate
+	}
+
+	repeat := opts.Repeat
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	goldenPath := filepath.Join(opts.GoldenDir, eval.ID+".json")
+
+	var attempts []AttemptResult
+	if opts.Replay {
+		golden, err := loadGolden(goldenPath)
+		if err != nil {
+			return Result{Status: Fail, Reason: fmt.Sprintf("replay: %v", err)}
+		}
+		for _, output := range golden.Outputs {
+			attempts = append(attempts, judgeAttempt(ctx, output, clauses))
+		}
+	} else {
+		outputs := make([]string, 0, repeat)
+		for i := 0; i < repeat; i++ {
+			output, err := completeWithRetry(ctx, eval)
+			if err != nil {
+				attempts = append(attempts, AttemptResult{Reason: err.Error()})
+				continue
+			}
+			outputs = append(outputs, output)
+			attempts = append(attempts, judgeAttempt(ctx, output, clauses))
+		}
+
+		if opts.Record {
+			if err := saveGolden(goldenPath, goldenRecord{EvalID: eval.ID, Outputs: outputs}); err != nil {
+				return Result{Status: Fail, Reason: fmt.Sprintf("record: %v", err), Attempts: attempts}
+			}
+		}
+	}
+
+	if len(attempts) == 0 {
+		return Result{Status: Fail, Reason: "no attempts completed"}
+	}
+
+	passed := 0
+	for _, a := range attempts {
+		if a.Passed {
+			passed++
+		}
+	}
+	passRate := float64(passed) / float64(len(attempts))
+
+	if passRate < minPassRate {
+		return Result{
+			Status:   Fail,
+			Reason:   fmt.Sprintf("pass rate %.0f%% (%d/%d attempts) below min_pass_rate %.0f%%", passRate*100, passed, len(attempts), minPassRate*100),
+			Attempts: attempts,
+		}
+	}
+	return Result{
+		Status:   Pass,
+		Reason:   fmt.Sprintf("pass rate %.0f%% (%d/%d attempts)", passRate*100, passed, len(attempts)),
+		Attempts: attempts,
+	}
+}
+
+func judgeAttempt(ctx context.Context, output string, clauses []ThenClause) AttemptResult {
+	ok, reason := evaluateThenClauses(ctx, clauses, output)
+	return AttemptResult{Output: output, Passed: ok, Reason: reason}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// fakeLLMProvider returns outputs[i] on the i-th Complete call (cycling if
+// there are more calls than outputs), failing transiently `failures` times
+// first. Embed looks text up in a fixed table.
+type fakeLLMProvider struct {
+	outputs    []string
+	failures   int
+	calls      int
+	embeddings map[string][]float64
+}
+
+func (f *fakeLLMProvider) Complete(ctx context.Context, eval Eval) (string, error) {
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return "", &ProviderError{StatusCode: 503, Err: errors.New("try again")}
+	}
+	if len(f.outputs) == 0 {
+		return "", errors.New("fakeLLMProvider: no outputs configured")
+	}
+	out := f.outputs[(f.calls-1)%len(f.outputs)]
+	return out, nil
+}
+
+func (f *fakeLLMProvider) Embed(ctx context.Context, text string) ([]float64, error) {
+	if v, ok := f.embeddings[text]; ok {
+		return v, nil
+	}
+	return []float64{0, 0}, nil
+}
+
+func withFakeProvider(t *testing.T, p LLMProvider) {
+	t.Helper()
+	original := llmProvider
+	llmProvider = p
+	t.Cleanup(func() { llmProvider = original })
+}
+
+func TestEvaluateThenClausesAllAssertionTypes(t *testing.T) {
+	withFakeProvider(t, &fakeLLMProvider{
+		embeddings: map[string][]float64{
+			"the output":   {1, 0},
+			"close enough": {1, 0.01},
+			"unrelated":    {0, 1},
+		},
+	})
+
+	cases := []struct {
+		name   string
+		then   string
+		output string
+		want   bool
+	}{
+		{"equals pass", `{"equals": "hello"}`, "hello", true},
+		{"equals fail", `{"equals": "hello"}`, "goodbye", false},
+		{"contains pass", `{"contains": "ell"}`, "hello", true},
+		{"contains fail", `{"contains": "xyz"}`, "hello", false},
+		{"regex pass", `{"regex": "^h.*o$"}`, "hello", true},
+		{"regex fail", `{"regex": "^z"}`, "hello", false},
+		{"json_schema pass", `{"json_schema": {"type": "object", "required": ["name"]}}`, `{"name": "a"}`, true},
+		{"json_schema fail missing field", `{"json_schema": {"type": "object", "required": ["name"]}}`, `{}`, false},
+		{"json_schema fail not json", `{"json_schema": {"type": "object"}}`, "not json", false},
+		{"semantic_similarity pass", `{"semantic_similarity": {"text": "close enough", "threshold": 0.9}}`, "the output", true},
+		{"semantic_similarity fail", `{"semantic_similarity": {"text": "unrelated", "threshold": 0.9}}`, "the output", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clauses, err := parseThenClauses(json.RawMessage(tc.then))
+			if err != nil {
+				t.Fatalf("parseThenClauses: %v", err)
+			}
+			ok, reason := evaluateThenClauses(context.Background(), clauses, tc.output)
+			if ok != tc.want {
+				t.Fatalf("evaluateThenClauses = %v (%q), want %v", ok, reason, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseThenClausesRejectsEmptyAssertions(t *testing.T) {
+	for _, raw := range []string{`[]`, `null`} {
+		if _, err := parseThenClauses(json.RawMessage(raw)); err == nil {
+			t.Errorf("parseThenClauses(%s) = nil error, want an error", raw)
+		}
+	}
+}
+
+func TestRunLLMEvalPassRate(t *testing.T) {
+	withFakeProvider(t, &fakeLLMProvider{outputs: []string{"yes", "no", "yes", "yes"}})
+
+	passRate := 0.5
+	eval := Eval{
+		ID:          "e1",
+		Category:    "llm",
+		Then:        json.RawMessage(`{"contains": "yes"}`),
+		MinPassRate: &passRate,
+	}
+
+	ctx := withLLMOptions(context.Background(), llmRunOptions{Repeat: 4, MinPassRate: 1.0, GoldenDir: t.TempDir()})
+	result := runLLMEval(ctx, eval)
+
+	if result.Status != Pass {
+		t.Fatalf("status = %v, reason = %q, want Pass (3/4 >= 0.5)", result.Status, result.Reason)
+	}
+	if len(result.Attempts) != 4 {
+		t.Fatalf("got %d attempts, want 4", len(result.Attempts))
+	}
+}
+
+func TestRunLLMEvalBelowMinPassRateFails(t *testing.T) {
+	withFakeProvider(t, &fakeLLMProvider{outputs: []string{"no", "no", "yes", "no"}})
+
+	eval := Eval{
+		ID:       "e1",
+		Category: "llm",
+		Then:     json.RawMessage(`{"contains": "yes"}`),
+	}
+
+	ctx := withLLMOptions(context.Background(), llmRunOptions{Repeat: 4, MinPassRate: 1.0, GoldenDir: t.TempDir()})
+	result := runLLMEval(ctx, eval)
+
+	if result.Status != Fail {
+		t.Fatalf("status = %v, want Fail (1/4 < default min_pass_rate 1.0)", result.Status)
+	}
+}
+
+func TestRunLLMEvalExplicitZeroMinPassRateIsHonored(t *testing.T) {
+	withFakeProvider(t, &fakeLLMProvider{outputs: []string{"no", "no", "no"}})
+
+	zero := 0.0
+	eval := Eval{
+		ID:          "e1",
+		Category:    "llm",
+		Then:        json.RawMessage(`{"contains": "yes"}`),
+		MinPassRate: &zero,
+	}
+
+	ctx := withLLMOptions(context.Background(), llmRunOptions{Repeat: 3, MinPassRate: 1.0, GoldenDir: t.TempDir()})
+	result := runLLMEval(ctx, eval)
+
+	if result.Status != Pass {
+		t.Fatalf("status = %v, want Pass: explicit min_pass_rate 0 should accept an all-failing run", result.Status)
+	}
+}
+
+func TestCompleteWithRetryRecoversFromTransientError(t *testing.T) {
+	provider := &fakeLLMProvider{failures: 2, outputs: []string{"ok"}}
+	withFakeProvider(t, provider)
+
+	output, err := completeWithRetry(context.Background(), Eval{ID: "e1"})
+	if err != nil {
+		t.Fatalf("completeWithRetry: %v", err)
+	}
+	if output != "ok" {
+		t.Fatalf("output = %q, want %q", output, "ok")
+	}
+	if provider.calls != 3 {
+		t.Fatalf("provider called %d times, want 3 (2 failures + 1 success)", provider.calls)
+	}
+}
+
+func TestCompleteWithRetryDoesNotRetryPermanentError(t *testing.T) {
+	provider := &permanentErrorProvider{}
+	withFakeProvider(t, provider)
+
+	_, err := completeWithRetry(context.Background(), Eval{ID: "e1"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if provider.calls != 1 {
+		t.Fatalf("provider called %d times, want 1 (no retries on a non-transient error)", provider.calls)
+	}
+}
+
+type permanentErrorProvider struct {
+	calls int
+}
+
+func (p *permanentErrorProvider) Complete(ctx context.Context, eval Eval) (string, error) {
+	p.calls++
+	return "", &ProviderError{StatusCode: 400, Err: errors.New("bad request")}
+}
+
+func (p *permanentErrorProvider) Embed(ctx context.Context, text string) ([]float64, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRunLLMEvalRecordThenReplay(t *testing.T) {
+	goldenDir := t.TempDir()
+
+	withFakeProvider(t, &fakeLLMProvider{outputs: []string{"yes", "yes"}})
+	eval := Eval{ID: "record-replay-eval", Category: "llm", Then: json.RawMessage(`{"contains": "yes"}`)}
+
+	recordCtx := withLLMOptions(context.Background(), llmRunOptions{Repeat: 2, MinPassRate: 1.0, Record: true, GoldenDir: goldenDir})
+	recorded := runLLMEval(recordCtx, eval)
+	if recorded.Status != Pass {
+		t.Fatalf("record run status = %v, want Pass", recorded.Status)
+	}
+
+	// Swap in a provider whose every output fails the assertion, to prove replay never calls it.
+	withFakeProvider(t, &fakeLLMProvider{outputs: []string{"no"}})
+	replayCtx := withLLMOptions(context.Background(), llmRunOptions{Repeat: 2, MinPassRate: 1.0, Replay: true, GoldenDir: goldenDir})
+	replayed := runLLMEval(replayCtx, eval)
+	if replayed.Status != Pass {
+		t.Fatalf("replay run status = %v, reason = %q, want Pass against recorded golden output", replayed.Status, replayed.Reason)
+	}
+}
@@ -0,0 +1,27 @@
+// ABOUTME: Per-run LLM eval options (--repeat, --min-pass-rate, --record, --replay) threaded via context.
+// ABOUTME: Carried on ctx instead of widening CategoryRunner's signature, which plugins/subprocesses already depend on.
+
+package main
+
+import "context"
+
+type llmOptionsKey struct{}
+
+type llmRunOptions struct {
+	Repeat      int
+	MinPassRate float64
+	Record      bool
+	Replay      bool
+	GoldenDir   string
+}
+
+func withLLMOptions(ctx context.Context, opts llmRunOptions) context.Context {
+	return context.WithValue(ctx, llmOptionsKey{}, opts)
+}
+
+func llmOptionsFromContext(ctx context.Context) llmRunOptions {
+	if opts, ok := ctx.Value(llmOptionsKey{}).(llmRunOptions); ok {
+		return opts
+	}
+	return llmRunOptions{Repeat: 1, MinPassRate: 1.0, GoldenDir: "golden"}
+}
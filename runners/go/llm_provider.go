@@ -0,0 +1,112 @@
+// ABOUTME: LLM provider abstraction for the llm eval category, plus retry-with-backoff on transient errors.
+// ABOUTME: Swap in a real mux provider at startup with SetLLMProvider; the default just reports itself unconfigured.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// LLMProvider executes the `when` clause of an LLM eval against a real
+// model and can embed text for semantic_similarity assertions. A concrete
+// mux provider should call SetLLMProvider during startup; nothing in this
+// package wires one up by default so the runner stays dependency-free.
+type LLMProvider interface {
+	Complete(ctx context.Context, eval Eval) (string, error)
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+var llmProvider LLMProvider = unconfiguredProvider{}
+
+// SetLLMProvider installs the provider used by llm category evals.
+func SetLLMProvider(p LLMProvider) {
+	llmProvider = p
+}
+
+type unconfiguredProvider struct{}
+
+func (unconfiguredProvider) Complete(ctx context.Context, eval Eval) (string, error) {
+	return "", fmt.Errorf("no LLM provider configured (call SetLLMProvider before running llm evals)")
+}
+
+func (unconfiguredProvider) Embed(ctx context.Context, text string) ([]float64, error) {
+	return nil, fmt.Errorf("no LLM provider configured (call SetLLMProvider before running llm evals)")
+}
+
+// ProviderError carries the HTTP status a provider call failed with, so
+// retry logic can tell a transient 429/5xx from a permanent error.
+type ProviderError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("provider error (status %d): %v", e.StatusCode, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+func isTransientProviderError(err error) bool {
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.StatusCode == 429 || perr.StatusCode >= 500
+	}
+	return false
+}
+
+const (
+	llmMaxRetries     = 3
+	llmInitialBackoff = 250 * time.Millisecond
+)
+
+// completeWithRetry calls the provider, retrying with exponential backoff
+// on transient (429/5xx) errors and giving up immediately on anything else.
+func completeWithRetry(ctx context.Context, eval Eval) (string, error) {
+	backoff := llmInitialBackoff
+	var lastErr error
+
+	for attempt := 0; attempt <= llmMaxRetries; attempt++ {
+		output, err := llmProvider.Complete(ctx, eval)
+		if err == nil {
+			return output, nil
+		}
+		lastErr = err
+		if !isTransientProviderError(err) || attempt == llmMaxRetries {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	return "", lastErr
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, in
+// [-1, 1]. Mismatched lengths or zero vectors report 0 similarity.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
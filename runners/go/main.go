@@ -5,21 +5,31 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Eval struct {
-	ID          string          `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Category    string          `json:"category"`
-	Provider    string          `json:"provider,omitempty"`
-	RequiresKey string          `json:"requires_key,omitempty"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Provider    string `json:"provider,omitempty"`
+	RequiresKey string `json:"requires_key,omitempty"`
+	// TimeoutMs is a pointer so an explicit `"timeout_ms": 0` (disable the
+	// deadline) can be told apart from the field being omitted (inherit
+	// the CLI --timeout default).
+	TimeoutMs *int `json:"timeout_ms,omitempty"`
+	// MinPassRate is a pointer for the same reason: an explicit
+	// `"min_pass_rate": 0` must be told apart from the field being omitted
+	// (inherit the CLI --min-pass-rate default).
+	MinPassRate *float64        `json:"min_pass_rate,omitempty"`
 	Given       json.RawMessage `json:"given"`
 	When        json.RawMessage `json:"when"`
 	Then        json.RawMessage `json:"then"`
@@ -34,17 +44,19 @@ const (
 )
 
 type Result struct {
-	Status EvalResult
-	Reason string
+	Status   EvalResult
+	Reason   string
+	Attempts []AttemptResult
 }
 
 // JSON output structures
 type JsonEvalResult struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Category string  `json:"category"`
-	Status   string  `json:"status"`
-	Reason   *string `json:"reason,omitempty"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Category string          `json:"category"`
+	Status   string          `json:"status"`
+	Reason   *string         `json:"reason,omitempty"`
+	Attempts []AttemptResult `json:"attempts,omitempty"`
 }
 
 type JsonSummary struct {
@@ -77,94 +89,82 @@ func main() {
 	id := flag.String("id", "", "Filter by specific eval ID")
 	verbose := flag.Bool("verbose", false, "Verbose output")
 	failuresOnly := flag.Bool("failures-only", false, "Only show failures")
-	jsonOutput := flag.Bool("json", false, "Output results as JSON")
+	jsonOutput := flag.Bool("json", false, "Output results as JSON (deprecated, use --format json)")
+	format := flag.String("format", "", "Output format: text|json|junit|tap|md (default text)")
+	timeout := flag.Int("timeout", 0, "Default per-eval timeout in milliseconds (0 disables the deadline)")
+	parallel := flag.Int("parallel", 1, "Number of evals to run concurrently")
+	pluginPath := flag.String("plugin", "", "Path to a Go plugin (.so) exporting Register(func(string, CategoryRunner)) to add eval categories")
+	repeat := flag.Int("repeat", 1, "Number of times to repeat each LLM eval")
+	minPassRate := flag.Float64("min-pass-rate", 1.0, "Default minimum pass rate for LLM evals run with --repeat")
+	record := flag.Bool("record", false, "Record LLM eval outputs to --golden-dir instead of just asserting")
+	replay := flag.Bool("replay", false, "Assert LLM evals against recorded --golden-dir output instead of calling the provider")
+	goldenDir := flag.String("golden-dir", "golden", "Directory for recorded LLM eval outputs (--record/--replay)")
 	flag.Parse()
 
-	evals, err := loadEvals(*evalsPath, *category, *id)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading evals: %v\n", err)
+	if *record && *replay {
+		fmt.Fprintln(os.Stderr, "Error: --record and --replay are mutually exclusive")
 		os.Exit(1)
 	}
 
-	if !*jsonOutput {
-		fmt.Fprintf(os.Stderr, "\n%s%sRunning%s %d evals\n\n", colorBold, colorCyan, colorReset, len(evals))
-	}
-
-	var passed, failed, skipped int
-	var jsonResults []JsonEvalResult
-
-	for _, eval := range evals {
-		result := runEval(eval, *verbose)
-
-		var status string
-		var reason *string
-
-		switch result.Status {
-		case Pass:
-			passed++
-			status = "pass"
-		case Fail:
-			failed++
-			status = "fail"
-			reason = &result.Reason
-		case Skip:
-			skipped++
-			status = "skip"
-			reason = &result.Reason
+	if *pluginPath != "" {
+		if err := loadCategoryPlugin(*pluginPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading plugin: %v\n", err)
+			os.Exit(1)
 		}
+	}
 
+	resolvedFormat := *format
+	if resolvedFormat == "" {
 		if *jsonOutput {
-			jsonResults = append(jsonResults, JsonEvalResult{
-				ID:       eval.ID,
-				Name:     eval.Name,
-				Category: eval.Category,
-				Status:   status,
-				Reason:   reason,
-			})
+			resolvedFormat = "json"
 		} else {
-			switch result.Status {
-			case Pass:
-				if !*failuresOnly {
-					fmt.Printf("%s%sPASS%s %s - %s\n", colorBold, colorGreen, colorReset, eval.ID, eval.Name)
-				}
-			case Fail:
-				fmt.Printf("%s%sFAIL%s %s - %s\n       %s%s%s\n",
-					colorBold, colorRed, colorReset, eval.ID, eval.Name,
-					colorDim, result.Reason, colorReset)
-			case Skip:
-				if !*failuresOnly {
-					fmt.Printf("%s%sSKIP%s %s - %s\n       %s%s%s\n",
-						colorBold, colorYellow, colorReset, eval.ID, eval.Name,
-						colorDim, result.Reason, colorReset)
-				}
-			}
+			resolvedFormat = "text"
 		}
 	}
 
-	if *jsonOutput {
-		report := JsonReport{
-			Runner:  "go",
-			Results: jsonResults,
-			Summary: JsonSummary{
-				Passed:  passed,
-				Failed:  failed,
-				Skipped: skipped,
-				Total:   len(evals),
-			},
-		}
-		output, _ := json.MarshalIndent(report, "", "  ")
-		fmt.Println(string(output))
-	} else {
-		fmt.Printf("\n%sResults%s: %s%d%s passed, ", colorBold, colorReset, colorGreen, passed, colorReset)
-		if failed > 0 {
-			fmt.Printf("%s%d%s failed, ", colorRed, failed, colorReset)
-		} else {
-			fmt.Printf("%d failed, ", failed)
+	reporter, err := newReporter(resolvedFormat, os.Stdout, os.Stderr, *failuresOnly)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	evals, err := loadEvals(*evalsPath, *category, *id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading evals: %v\n", err)
+		os.Exit(1)
+	}
+
+	for i := range evals {
+		if evals[i].TimeoutMs == nil {
+			evals[i].TimeoutMs = timeout
 		}
-		fmt.Printf("%s%d%s skipped\n\n", colorYellow, skipped, colorReset)
 	}
 
-	if failed > 0 {
+	ctx := withLLMOptions(context.Background(), llmRunOptions{
+		Repeat:      *repeat,
+		MinPassRate: *minPassRate,
+		Record:      *record,
+		Replay:      *replay,
+		GoldenDir:   *goldenDir,
+	})
+
+	reporter.StartRun(len(evals))
+
+	agg := runEvals(ctx, evals, *parallel, *verbose, reporter)
+
+	summary := JsonSummary{
+		Passed:  agg.passed,
+		Failed:  agg.failed,
+		Skipped: agg.skipped,
+		Total:   len(evals),
+	}
+
+	if err := reporter.Finish(summary); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if agg.failed > 0 {
 		os.Exit(1)
 	}
 }
@@ -232,72 +232,99 @@ func loadEvals(path, categoryFilter, idFilter string) ([]Eval, error) {
 	return evals, nil
 }
 
-func runEval(eval Eval, verbose bool) Result {
+// runEvalWithTimeout enforces eval.TimeoutMs (if any) around runEval.
+//
+// It mirrors netstack's deadline pattern: a time.Timer races against a
+// result channel fed by a goroutine running the eval body, and a context
+// cancellation (closing ctx.Done, our "cancel channel") signals any
+// in-flight HTTP/LLM calls to abort the moment the timer wins the race.
+func runEvalWithTimeout(ctx context.Context, eval Eval, verbose bool) Result {
+	timeoutMs := 0
+	if eval.TimeoutMs != nil {
+		timeoutMs = *eval.TimeoutMs
+	}
+	if timeoutMs <= 0 {
+		return runEval(ctx, eval, verbose)
+	}
+
+	evalCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	resultCh := make(chan Result, 1)
+	go func() {
+		resultCh <- runEval(evalCtx, eval, verbose)
+	}()
+
+	timer := time.NewTimer(time.Duration(timeoutMs) * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case result := <-resultCh:
+		return result
+	case <-timer.C:
+		cancel()
+		return Result{Status: Fail, Reason: fmt.Sprintf("timeout after %d ms", timeoutMs)}
+	}
+}
+
+func runEval(ctx context.Context, eval Eval, verbose bool) Result {
 	// Check for required API keys
 	if eval.RequiresKey != "" {
 		if os.Getenv(eval.RequiresKey) == "" {
-			return Result{Skip, fmt.Sprintf("%s not set", eval.RequiresKey)}
+			return Result{Status: Skip, Reason: fmt.Sprintf("%s not set", eval.RequiresKey)}
 		}
 	}
 
 	if verbose {
-		fmt.Printf("  given: %s\n", eval.Given)
-		fmt.Printf("  when: %s\n", eval.When)
-		fmt.Printf("  then: %s\n", eval.Then)
+		// Debug output always goes to stderr, never stdout: stdout is the
+		// machine-parseable report stream (json/junit/tap/md), and these
+		// lines aren't ordered against it under --parallel anyway.
+		fmt.Fprintf(os.Stderr, "  given: %s\n", eval.Given)
+		fmt.Fprintf(os.Stderr, "  when: %s\n", eval.When)
+		fmt.Fprintf(os.Stderr, "  then: %s\n", eval.Then)
 	}
 
-	// Dispatch based on category
-	switch eval.Category {
-	case "tools":
-		return runToolEval(eval)
-	case "hooks":
-		return runHookEval(eval)
-	case "agent":
-		return runAgentEval(eval)
-	case "subagent":
-		return runSubagentEval(eval)
-	case "transcript":
-		return runTranscriptEval(eval)
-	case "mcp":
-		return runMCPEval(eval)
-	case "llm":
-		return runLLMEval(eval)
-	default:
-		return Result{Skip, fmt.Sprintf("Unknown category: %s", eval.Category)}
+	// Dispatch via the category registry, falling back to a mux-eval-<category>
+	// subprocess (if one is on PATH) before giving up.
+	runner, ok := lookupRunner(eval.Category)
+	if !ok {
+		if sub, found := findSubprocessRunner(eval.Category); found {
+			Register(eval.Category, sub)
+			runner, ok = sub, true
+		}
+	}
+	if !ok {
+		return Result{Status: Skip, Reason: fmt.Sprintf("Unknown category: %s", eval.Category)}
 	}
+	return runner(ctx, eval)
 }
 
-func runToolEval(eval Eval) Result {
+func runToolEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux tool registry
-	return Result{Skip, "Tool eval implementation pending"}
+	return Result{Status: Skip, Reason: "Tool eval implementation pending"}
 }
 
-func runHookEval(eval Eval) Result {
+func runHookEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux hook system
-	return Result{Skip, "Hook eval implementation pending"}
+	return Result{Status: Skip, Reason: "Hook eval implementation pending"}
 }
 
-func runAgentEval(eval Eval) Result {
+func runAgentEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux agent/orchestrator
-	return Result{Skip, "Agent eval implementation pending"}
+	return Result{Status: Skip, Reason: "Agent eval implementation pending"}
 }
 
-func runSubagentEval(eval Eval) Result {
+func runSubagentEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux subagent system
-	return Result{Skip, "Subagent eval implementation pending"}
+	return Result{Status: Skip, Reason: "Subagent eval implementation pending"}
 }
 
-func runTranscriptEval(eval Eval) Result {
+func runTranscriptEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux transcript persistence
-	return Result{Skip, "Transcript eval implementation pending"}
+	return Result{Status: Skip, Reason: "Transcript eval implementation pending"}
 }
 
-func runMCPEval(eval Eval) Result {
+func runMCPEval(ctx context.Context, eval Eval) Result {
 	// TODO: Implement against mux MCP client
-	return Result{Skip, "MCP eval implementation pending"}
-}
-
-func runLLMEval(eval Eval) Result {
-	// TODO: Implement against mux LLM providers
-	return Result{Skip, "LLM eval implementation pending"}
+	return Result{Status: Skip, Reason: "MCP eval implementation pending"}
 }
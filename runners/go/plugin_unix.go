@@ -0,0 +1,33 @@
+// ABOUTME: Loads --plugin .so files via Go's plugin package (linux/darwin/freebsd only).
+
+//go:build linux || darwin || freebsd
+
+package main
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// loadCategoryPlugin opens a Go plugin and calls its exported Register
+// function, passing our package-level Register so the plugin can add its
+// own categories (e.g. "rag", "browser") to the registry.
+func loadCategoryPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("loading plugin %s: %w", path, err)
+	}
+
+	sym, err := p.Lookup("Register")
+	if err != nil {
+		return fmt.Errorf("plugin %s has no exported Register symbol: %w", path, err)
+	}
+
+	registerFn, ok := sym.(func(func(string, CategoryRunner)))
+	if !ok {
+		return fmt.Errorf("plugin %s: Register has the wrong signature (want func(func(string, CategoryRunner)))", path)
+	}
+
+	registerFn(Register)
+	return nil
+}
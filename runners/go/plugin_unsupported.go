@@ -0,0 +1,11 @@
+// ABOUTME: Stub for platforms where Go's plugin package isn't available (e.g. windows).
+
+//go:build !linux && !darwin && !freebsd
+
+package main
+
+import "fmt"
+
+func loadCategoryPlugin(path string) error {
+	return fmt.Errorf("plugin loading is not supported on this platform (tried %s); build a mux-eval-<category> subprocess instead", path)
+}
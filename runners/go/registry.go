@@ -0,0 +1,42 @@
+// ABOUTME: Category runner registry so external packages can add eval categories.
+// ABOUTME: Built-ins register themselves in init(); --plugin and subprocess runners register at startup/on demand.
+
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// CategoryRunner executes a single eval belonging to some category.
+type CategoryRunner func(ctx context.Context, eval Eval) Result
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]CategoryRunner{}
+)
+
+// Register adds (or replaces) the runner for category. Plugins and
+// subprocess adapters call this the same way the built-ins do in init().
+func Register(category string, runner CategoryRunner) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[category] = runner
+}
+
+func lookupRunner(category string) (CategoryRunner, bool) {
+	registryMu.RLock()
+	runner, ok := registry[category]
+	registryMu.RUnlock()
+	return runner, ok
+}
+
+func init() {
+	Register("tools", runToolEval)
+	Register("hooks", runHookEval)
+	Register("agent", runAgentEval)
+	Register("subagent", runSubagentEval)
+	Register("transcript", runTranscriptEval)
+	Register("mcp", runMCPEval)
+	Register("llm", runLLMEval)
+}
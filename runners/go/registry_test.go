@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegisterAndLookupRunner(t *testing.T) {
+	Register("test-registry-category", func(ctx context.Context, eval Eval) Result {
+		return Result{Status: Pass, Reason: "ran " + eval.ID}
+	})
+
+	runner, ok := lookupRunner("test-registry-category")
+	if !ok {
+		t.Fatal("expected runner to be registered")
+	}
+
+	result := runner(context.Background(), Eval{ID: "e1"})
+	if result.Status != Pass || result.Reason != "ran e1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestLookupRunnerMissingCategory(t *testing.T) {
+	if _, ok := lookupRunner("definitely-not-a-registered-category"); ok {
+		t.Fatal("expected lookup to fail for an unregistered category")
+	}
+}
+
+func TestRunEvalUnknownCategorySkips(t *testing.T) {
+	result := runEval(context.Background(), Eval{Category: "definitely-not-a-registered-category"}, false)
+	if result.Status != Skip {
+		t.Fatalf("status = %v, want Skip", result.Status)
+	}
+}
+
+func TestRunEvalDispatchesToRegisteredCategory(t *testing.T) {
+	Register("test-dispatch-category", func(ctx context.Context, eval Eval) Result {
+		return Result{Status: Fail, Reason: "from registry"}
+	})
+
+	result := runEval(context.Background(), Eval{Category: "test-dispatch-category"}, false)
+	if result.Status != Fail || result.Reason != "from registry" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestFindSubprocessRunnerMissingBinary(t *testing.T) {
+	if _, ok := findSubprocessRunner("no-such-mux-eval-category"); ok {
+		t.Fatal("expected no subprocess runner to be found for a binary that doesn't exist on PATH")
+	}
+}
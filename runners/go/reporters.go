@@ -0,0 +1,274 @@
+// ABOUTME: Reporter implementations for the --format flag (text, json, junit, tap, md).
+// ABOUTME: Each Reporter turns the run's eval results into one CI-consumable shape.
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Reporter receives eval results as the run progresses and renders them in
+// whatever shape that format needs. StartRun/Finish bracket the run so
+// formats that need a header (TAP's plan line) or a trailer (the JSON
+// summary object) can emit it at the right time.
+type Reporter interface {
+	StartRun(total int)
+	RecordResult(eval Eval, result Result, duration time.Duration)
+	Finish(summary JsonSummary) error
+}
+
+func newReporter(format string, out, errOut io.Writer, failuresOnly bool) (Reporter, error) {
+	switch format {
+	case "text":
+		return &textReporter{out: out, errOut: errOut, failuresOnly: failuresOnly}, nil
+	case "json":
+		return &jsonReporter{out: out}, nil
+	case "junit":
+		return &junitReporter{out: out}, nil
+	case "tap":
+		return &tapReporter{out: out}, nil
+	case "md":
+		return &mdReporter{out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, json, junit, tap, or md)", format)
+	}
+}
+
+// textReporter is the original colored human-readable reporter.
+type textReporter struct {
+	out          io.Writer
+	errOut       io.Writer
+	failuresOnly bool
+}
+
+func (t *textReporter) StartRun(total int) {
+	fmt.Fprintf(t.errOut, "\n%s%sRunning%s %d evals\n\n", colorBold, colorCyan, colorReset, total)
+}
+
+func (t *textReporter) RecordResult(eval Eval, result Result, _ time.Duration) {
+	switch result.Status {
+	case Pass:
+		if !t.failuresOnly {
+			fmt.Fprintf(t.out, "%s%sPASS%s %s - %s\n", colorBold, colorGreen, colorReset, eval.ID, eval.Name)
+		}
+	case Fail:
+		fmt.Fprintf(t.out, "%s%sFAIL%s %s - %s\n       %s%s%s\n",
+			colorBold, colorRed, colorReset, eval.ID, eval.Name,
+			colorDim, result.Reason, colorReset)
+	case Skip:
+		if !t.failuresOnly {
+			fmt.Fprintf(t.out, "%s%sSKIP%s %s - %s\n       %s%s%s\n",
+				colorBold, colorYellow, colorReset, eval.ID, eval.Name,
+				colorDim, result.Reason, colorReset)
+		}
+	}
+}
+
+func (t *textReporter) Finish(summary JsonSummary) error {
+	fmt.Fprintf(t.out, "\n%sResults%s: %s%d%s passed, ", colorBold, colorReset, colorGreen, summary.Passed, colorReset)
+	if summary.Failed > 0 {
+		fmt.Fprintf(t.out, "%s%d%s failed, ", colorRed, summary.Failed, colorReset)
+	} else {
+		fmt.Fprintf(t.out, "%d failed, ", summary.Failed)
+	}
+	fmt.Fprintf(t.out, "%s%d%s skipped\n\n", colorYellow, summary.Skipped, colorReset)
+	return nil
+}
+
+// jsonReporter reproduces the original --json output.
+type jsonReporter struct {
+	out     io.Writer
+	results []JsonEvalResult
+}
+
+func (j *jsonReporter) StartRun(total int) {}
+
+func (j *jsonReporter) RecordResult(eval Eval, result Result, duration time.Duration) {
+	var status string
+	var reason *string
+	switch result.Status {
+	case Pass:
+		status = "pass"
+	case Fail:
+		status = "fail"
+		reason = &result.Reason
+	case Skip:
+		status = "skip"
+		reason = &result.Reason
+	}
+	j.results = append(j.results, JsonEvalResult{
+		ID:       eval.ID,
+		Name:     eval.Name,
+		Category: eval.Category,
+		Status:   status,
+		Reason:   reason,
+		Attempts: result.Attempts,
+	})
+	_ = duration
+}
+
+func (j *jsonReporter) Finish(summary JsonSummary) error {
+	report := JsonReport{
+		Runner:  "go",
+		Results: j.results,
+		Summary: summary,
+	}
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(j.out, string(output))
+	return err
+}
+
+// junitReporter renders a JUnit XML <testsuite>, the format most CI
+// dashboards (GitHub Actions, GitLab, Jenkins) already know how to ingest.
+type junitReporter struct {
+	out       io.Writer
+	testCases []junitTestCase
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Classname string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+func (j *junitReporter) StartRun(total int) {}
+
+func (j *junitReporter) RecordResult(eval Eval, result Result, duration time.Duration) {
+	tc := junitTestCase{
+		Classname: eval.Category,
+		Name:      fmt.Sprintf("%s - %s", eval.ID, eval.Name),
+		Time:      fmt.Sprintf("%.3f", duration.Seconds()),
+	}
+	switch result.Status {
+	case Fail:
+		tc.Failure = &junitFailure{Message: result.Reason, Text: result.Reason}
+	case Skip:
+		tc.Skipped = &junitSkipped{Message: result.Reason}
+	}
+	j.testCases = append(j.testCases, tc)
+}
+
+func (j *junitReporter) Finish(summary JsonSummary) error {
+	suite := junitTestSuite{
+		Name:      "mux-evals",
+		Tests:     summary.Total,
+		Failures:  summary.Failed,
+		Skipped:   summary.Skipped,
+		TestCases: j.testCases,
+	}
+	output, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(j.out, xml.Header+string(output)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// tapReporter renders TAP version 13 (https://testanything.org/), consumed
+// by prove, tap-junit, and similar CI harnesses.
+type tapReporter struct {
+	out  io.Writer
+	next int
+}
+
+func (t *tapReporter) StartRun(total int) {
+	fmt.Fprintln(t.out, "TAP version 13")
+	fmt.Fprintf(t.out, "1..%d\n", total)
+}
+
+func (t *tapReporter) RecordResult(eval Eval, result Result, _ time.Duration) {
+	t.next++
+	switch result.Status {
+	case Pass:
+		fmt.Fprintf(t.out, "ok %d - %s\n", t.next, eval.Name)
+	case Skip:
+		fmt.Fprintf(t.out, "ok %d - %s # SKIP %s\n", t.next, eval.Name, result.Reason)
+	case Fail:
+		fmt.Fprintf(t.out, "not ok %d - %s\n", t.next, eval.Name)
+		fmt.Fprintln(t.out, "  ---")
+		fmt.Fprintf(t.out, "  message: %q\n", result.Reason)
+		fmt.Fprintf(t.out, "  category: %q\n", eval.Category)
+		fmt.Fprintln(t.out, "  ...")
+	}
+}
+
+func (t *tapReporter) Finish(summary JsonSummary) error {
+	return nil
+}
+
+// mdReporter renders a summary table suitable for $GITHUB_STEP_SUMMARY.
+type mdReporter struct {
+	out  io.Writer
+	rows []string
+}
+
+func (m *mdReporter) StartRun(total int) {}
+
+func (m *mdReporter) RecordResult(eval Eval, result Result, _ time.Duration) {
+	var status, reason string
+	switch result.Status {
+	case Pass:
+		status = "✅ pass"
+	case Fail:
+		status = "❌ fail"
+		reason = result.Reason
+	case Skip:
+		status = "⏭️ skip"
+		reason = result.Reason
+	}
+	m.rows = append(m.rows, fmt.Sprintf("| %s | %s | %s | %s | %s |",
+		escapeMarkdownCell(status), escapeMarkdownCell(eval.ID), escapeMarkdownCell(eval.Name),
+		escapeMarkdownCell(eval.Category), escapeMarkdownCell(reason)))
+}
+
+// escapeMarkdownCell makes s safe to interpolate into a single markdown
+// table cell: a literal "|" would otherwise open extra columns, and a
+// newline would break the row entirely.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	s = strings.ReplaceAll(s, "\r", " ")
+	return s
+}
+
+func (m *mdReporter) Finish(summary JsonSummary) error {
+	fmt.Fprintln(m.out, "| Status | ID | Name | Category | Reason |")
+	fmt.Fprintln(m.out, "|---|---|---|---|---|")
+	for _, row := range m.rows {
+		fmt.Fprintln(m.out, row)
+	}
+	fmt.Fprintf(m.out, "\n**Results:** %d passed, %d failed, %d skipped (%d total)\n",
+		summary.Passed, summary.Failed, summary.Skipped, summary.Total)
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextReporterFinish(t *testing.T) {
+	var out, errOut bytes.Buffer
+	r := &textReporter{out: &out, errOut: &errOut, failuresOnly: false}
+
+	r.StartRun(2)
+	r.RecordResult(Eval{ID: "e1", Name: "one"}, Result{Status: Pass}, time.Millisecond)
+	r.RecordResult(Eval{ID: "e2", Name: "two"}, Result{Status: Fail, Reason: "boom"}, time.Millisecond)
+	if err := r.Finish(JsonSummary{Passed: 1, Failed: 1, Total: 2}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	if !strings.Contains(errOut.String(), "Running") {
+		t.Errorf("expected run header on stderr, got %q", errOut.String())
+	}
+	if !strings.Contains(out.String(), "PASS") || !strings.Contains(out.String(), "e1") {
+		t.Errorf("missing PASS line: %q", out.String())
+	}
+	if !strings.Contains(out.String(), "FAIL") || !strings.Contains(out.String(), "boom") {
+		t.Errorf("missing FAIL line with reason: %q", out.String())
+	}
+	if !strings.Contains(out.String(), "1") {
+		t.Errorf("missing summary counts: %q", out.String())
+	}
+}
+
+func TestJSONReporterFinish(t *testing.T) {
+	var out bytes.Buffer
+	r := &jsonReporter{out: &out}
+
+	r.StartRun(1)
+	r.RecordResult(Eval{ID: "e1", Name: "one", Category: "tools"}, Result{Status: Fail, Reason: "nope"}, time.Millisecond)
+	if err := r.Finish(JsonSummary{Failed: 1, Total: 1}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	var report JsonReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(report.Results) != 1 || report.Results[0].Status != "fail" {
+		t.Fatalf("unexpected results: %+v", report.Results)
+	}
+	if report.Results[0].Reason == nil || *report.Results[0].Reason != "nope" {
+		t.Fatalf("unexpected reason: %+v", report.Results[0].Reason)
+	}
+	if report.Summary.Failed != 1 || report.Summary.Total != 1 {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+}
+
+func TestJUnitReporterFinish(t *testing.T) {
+	var out bytes.Buffer
+	r := &junitReporter{out: &out}
+
+	r.StartRun(2)
+	r.RecordResult(Eval{ID: "e1", Name: "one", Category: "tools"}, Result{Status: Pass}, 10*time.Millisecond)
+	r.RecordResult(Eval{ID: "e2", Name: "two", Category: "llm"}, Result{Status: Fail, Reason: "bad output"}, 20*time.Millisecond)
+	if err := r.Finish(JsonSummary{Passed: 1, Failed: 1, Total: 2}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal(out.Bytes(), &suite); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out.String())
+	}
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Fatalf("unexpected suite totals: %+v", suite)
+	}
+	if len(suite.TestCases) != 2 {
+		t.Fatalf("expected 2 testcases, got %d", len(suite.TestCases))
+	}
+	if suite.TestCases[1].Failure == nil || suite.TestCases[1].Failure.Message != "bad output" {
+		t.Fatalf("expected failure message on second testcase, got %+v", suite.TestCases[1].Failure)
+	}
+}
+
+func TestTAPReporterFormat(t *testing.T) {
+	var out bytes.Buffer
+	r := &tapReporter{out: &out}
+
+	r.StartRun(2)
+	r.RecordResult(Eval{ID: "e1", Name: "one"}, Result{Status: Pass}, time.Millisecond)
+	r.RecordResult(Eval{ID: "e2", Name: "two"}, Result{Status: Fail, Reason: "bad"}, time.Millisecond)
+	if err := r.Finish(JsonSummary{Passed: 1, Failed: 1, Total: 2}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if lines[0] != "TAP version 13" || lines[1] != "1..2" {
+		t.Fatalf("unexpected TAP header: %v", lines[:2])
+	}
+	if !strings.HasPrefix(lines[2], "ok 1 - one") {
+		t.Errorf("expected passing test line, got %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "not ok 2 - two") {
+		t.Errorf("expected failing test line, got %q", lines[3])
+	}
+}
+
+func TestMDReporterEscapesPipesAndNewlines(t *testing.T) {
+	var out bytes.Buffer
+	r := &mdReporter{out: &out}
+
+	r.StartRun(1)
+	r.RecordResult(Eval{ID: "e1", Name: "one"}, Result{Status: Fail, Reason: "expected \"a|b\" in output\nsecond line"}, time.Millisecond)
+	if err := r.Finish(JsonSummary{Failed: 1, Total: 1}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	var row string
+	for _, l := range lines {
+		if strings.Contains(l, "e1") {
+			row = l
+			break
+		}
+	}
+	if row == "" {
+		t.Fatalf("did not find data row in output: %q", out.String())
+	}
+	delimiterPipes := strings.Count(strings.ReplaceAll(row, "\\|", ""), "|")
+	if delimiterPipes != 6 {
+		t.Fatalf("row has wrong column count (unescaped pipe?): %q", row)
+	}
+	if strings.Contains(row, "\n") {
+		t.Fatalf("row contains a raw newline: %q", row)
+	}
+}
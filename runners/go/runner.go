@@ -0,0 +1,103 @@
+// ABOUTME: Worker-pool dispatch and result aggregation for the eval suite.
+// ABOUTME: Lets --parallel fan evals across goroutines while keeping output stably ordered.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// aggregator collects pass/fail/skip counts. Workers never touch it: only
+// the ordering loop in runEvals records into it, and mu guards the counts
+// against any other goroutine that reads or records them.
+type aggregator struct {
+	mu      sync.Mutex
+	passed  int
+	failed  int
+	skipped int
+}
+
+func (a *aggregator) recordCounts(status EvalResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch status {
+	case Pass:
+		a.passed++
+	case Fail:
+		a.failed++
+	case Skip:
+		a.skipped++
+	}
+}
+
+// indexedResult pairs a Result with its position in the original evals
+// slice so the printer can restore submission order regardless of which
+// worker finished first.
+type indexedResult struct {
+	index    int
+	eval     Eval
+	result   Result
+	duration time.Duration
+}
+
+// runEvals dispatches evals across a bounded pool of `parallel` workers and
+// hands results to reporter in the order the evals were submitted in, even
+// though workers may finish out of order. At parallel<=1 a single worker
+// consumes the queue, which reproduces the previous strictly-serial behavior.
+func runEvals(ctx context.Context, evals []Eval, parallel int, verbose bool, reporter Reporter) *aggregator {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	agg := &aggregator{}
+
+	jobs := make(chan int)
+	resultsCh := make(chan indexedResult, len(evals))
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				eval := evals[idx]
+				start := time.Now()
+				result := runEvalWithTimeout(ctx, eval, verbose)
+				resultsCh <- indexedResult{index: idx, eval: eval, result: result, duration: time.Since(start)}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range evals {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	// Buffer completions that arrive out of order until it's their turn.
+	pending := make(map[int]indexedResult)
+	next := 0
+	for ir := range resultsCh {
+		pending[ir.index] = ir
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			agg.recordCounts(ready.result.Status)
+			reporter.RecordResult(ready.eval, ready.result, ready.duration)
+		}
+	}
+
+	return agg
+}
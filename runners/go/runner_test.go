@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderingReporter records the order RecordResult is called in. runEvals
+// guarantees a single goroutine drives this, so no locking is needed for
+// the recording itself, but we still guard it since tests run with -race.
+type orderingReporter struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *orderingReporter) StartRun(total int) {}
+
+func (r *orderingReporter) RecordResult(eval Eval, result Result, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, eval.ID)
+}
+
+func (r *orderingReporter) Finish(summary JsonSummary) error { return nil }
+
+func TestRunEvalsPreservesSubmissionOrderUnderParallelism(t *testing.T) {
+	Register("test-sleep", func(ctx context.Context, eval Eval) Result {
+		var ms int
+		if err := json.Unmarshal(eval.Given, &ms); err != nil {
+			return Result{Status: Fail, Reason: err.Error()}
+		}
+		time.Sleep(time.Duration(ms) * time.Millisecond)
+		return Result{Status: Pass}
+	})
+
+	// Deliberately submitted slowest-first so, without the ordering
+	// buffer, a naive worker pool would report "c" and "b" before "a".
+	evals := []Eval{
+		{ID: "a", Category: "test-sleep", Given: json.RawMessage(`60`)},
+		{ID: "b", Category: "test-sleep", Given: json.RawMessage(`30`)},
+		{ID: "c", Category: "test-sleep", Given: json.RawMessage(`5`)},
+	}
+
+	rep := &orderingReporter{}
+	agg := runEvals(context.Background(), evals, 3, false, rep)
+
+	if agg.passed != 3 || agg.failed != 0 || agg.skipped != 0 {
+		t.Fatalf("aggregate counts = %+v, want 3 passed", agg)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(rep.ids) != len(want) {
+		t.Fatalf("recorded %d results, want %d", len(rep.ids), len(want))
+	}
+	for i, id := range want {
+		if rep.ids[i] != id {
+			t.Fatalf("result order = %v, want %v", rep.ids, want)
+		}
+	}
+}
+
+func TestRunEvalsAggregatesMixedStatuses(t *testing.T) {
+	Register("test-status", func(ctx context.Context, eval Eval) Result {
+		switch eval.ID {
+		case "pass-eval":
+			return Result{Status: Pass}
+		case "fail-eval":
+			return Result{Status: Fail, Reason: "boom"}
+		default:
+			return Result{Status: Skip, Reason: "skipped"}
+		}
+	})
+
+	evals := []Eval{
+		{ID: "pass-eval", Category: "test-status"},
+		{ID: "fail-eval", Category: "test-status"},
+		{ID: "skip-eval", Category: "test-status"},
+	}
+
+	agg := runEvals(context.Background(), evals, 1, false, &orderingReporter{})
+	if agg.passed != 1 || agg.failed != 1 || agg.skipped != 1 {
+		t.Fatalf("aggregate counts = %+v, want 1/1/1", agg)
+	}
+}
@@ -0,0 +1,66 @@
+// ABOUTME: Subprocess category runner adapter: spawns mux-eval-<category> and trades JSON over stdio.
+// ABOUTME: Works on every platform, so it's the fallback where Go's plugin package isn't available.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// subprocessResult is the wire shape a mux-eval-<category> binary writes to
+// stdout after reading an Eval as JSON on stdin.
+type subprocessResult struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// findSubprocessRunner looks for a mux-eval-<category> binary on PATH and,
+// if present, wraps it as a CategoryRunner.
+func findSubprocessRunner(category string) (CategoryRunner, bool) {
+	binary, err := exec.LookPath("mux-eval-" + category)
+	if err != nil {
+		return nil, false
+	}
+	return runViaSubprocess(binary), true
+}
+
+func runViaSubprocess(binary string) CategoryRunner {
+	return func(ctx context.Context, eval Eval) Result {
+		input, err := json.Marshal(eval)
+		if err != nil {
+			return Result{Status: Fail, Reason: fmt.Sprintf("%s: failed to marshal eval: %v", binary, err)}
+		}
+
+		cmd := exec.CommandContext(ctx, binary)
+		cmd.Stdin = bytes.NewReader(input)
+
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return Result{Status: Fail, Reason: fmt.Sprintf("%s: %v: %s", binary, err, strings.TrimSpace(stderr.String()))}
+		}
+
+		var wire subprocessResult
+		if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &wire); err != nil {
+			return Result{Status: Fail, Reason: fmt.Sprintf("%s: invalid response: %v", binary, err)}
+		}
+
+		switch wire.Status {
+		case "pass":
+			return Result{Status: Pass, Reason: wire.Reason}
+		case "fail":
+			return Result{Status: Fail, Reason: wire.Reason}
+		case "skip":
+			return Result{Status: Skip, Reason: wire.Reason}
+		default:
+			return Result{Status: Fail, Reason: fmt.Sprintf("%s: unknown status %q", binary, wire.Status)}
+		}
+	}
+}